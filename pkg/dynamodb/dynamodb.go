@@ -43,26 +43,29 @@ func (l *DynamoDBReposLister) List() ([]*repo.Repo, error) {
 		TableName: aws.String(l.DynamoDBTableName),
 	}
 
-	scanOutput, err := l.dynamoDBService.Scan(scanInput)
+	var pageErr error
+	err := l.dynamoDBService.ScanPages(scanInput, func(scanOutput *dynamodb.ScanOutput, lastPage bool) bool {
+		for _, scanOutputItem := range scanOutput.Items {
+			item := new(tableItem)
+			if pageErr = dynamodbattribute.UnmarshalMap(scanOutputItem, item); pageErr != nil {
+				return false
+			}
+
+			repo := &repo.Repo{
+				Name:     item.RepoName,
+				ReadOnly: false, // Currently all seem to be set to 'false', even for archived repos.
+			}
+			repos = append(repos, repo)
+		}
+		return !lastPage
+	})
 	if err != nil {
 		l.log.Errorf("failed to scan DynamoDB table: %v", err)
 		return nil, fmt.Errorf("scanning DynamoDB table: %w", err)
 	}
-
-	// TODO: Implement paging if result set exceeds 1MB in size
-
-	for _, scanOutputItem := range scanOutput.Items {
-		item := new(tableItem)
-		err = dynamodbattribute.UnmarshalMap(scanOutputItem, item)
-		if err != nil {
-			panic(fmt.Sprintf("Failed to unmarshal Record, %v", err))
-		}
-
-		repo := &repo.Repo{
-			Name:     item.RepoName,
-			ReadOnly: false, // Currently all seem to be set to 'false', even for archived repos.
-		}
-		repos = append(repos, repo)
+	if pageErr != nil {
+		l.log.Errorf("failed to unmarshal DynamoDB record: %v", pageErr)
+		return nil, fmt.Errorf("unmarshalling dynamodb record: %w", pageErr)
 	}
 
 	return repos, nil
@@ -1,21 +1,25 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/google/go-github/v29/github"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"github.com/telia-oss/concourse-github-lambda/pkg/manager"
+	"github.com/telia-oss/concourse-github-lambda/pkg/metrics"
 	"github.com/telia-oss/concourse-github-lambda/pkg/repo"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets"
 	"github.com/telia-oss/concourse-github-lambda/pkg/team"
 	"github.com/telia-oss/concourse-github-lambda/pkg/template"
 )
 
 // New lambda handler with the provided settings.
 func New(manager *manager.Manager,
+	store secrets.Store,
 	githubOrganisation string,
 	repoLister repo.Lister,
 	tokenTemplate string,
@@ -23,6 +27,7 @@ func New(manager *manager.Manager,
 	titleTemplate string,
 	logger *logrus.Logger) func(team.Team) error {
 	return func(team team.Team) error {
+		ctx := context.Background()
 		log := logger.WithFields(logrus.Fields{
 			"team": team.Name,
 		})
@@ -34,17 +39,25 @@ func New(manager *manager.Manager,
 			return fmt.Errorf("parsing token path template: %w", err)
 		}
 
+		tokenTimer := prometheus.NewTimer(metrics.RotationLatency.WithLabelValues(metrics.PhaseCreateAccessToken))
 		token, err := manager.CreateAccessToken(githubOrganisation)
+		tokenTimer.ObserveDuration()
 		if err != nil {
 			log.Warnf("failed to create access token: %s", err)
 			return fmt.Errorf("creating access token: %w", err)
 		}
-		if err := manager.WriteSecret(tokenPath, token); err != nil {
+
+		writeTokenTimer := prometheus.NewTimer(metrics.RotationLatency.WithLabelValues(metrics.PhaseWriteToken))
+		err = store.Write(ctx, tokenPath, token, nil)
+		writeTokenTimer.ObserveDuration()
+		if err != nil {
 			log.Warnf("failed to write access token: %s", err)
 			return fmt.Errorf("writing access token: %w", err)
 		}
 
+		listReposTimer := prometheus.NewTimer(metrics.RotationLatency.WithLabelValues(metrics.PhaseListRepos))
 		repos, err := repoLister.List()
+		listReposTimer.ObserveDuration()
 		if err != nil {
 			log.Warnf("failed to list repos: %v", err)
 			return fmt.Errorf("listing repos: %w", err)
@@ -68,10 +81,15 @@ func New(manager *manager.Manager,
 				continue
 			}
 
+			metrics.ReposProcessedTotal.Inc()
+
 			// Look for existing keys belonging to the team
+			listKeysTimer := prometheus.NewTimer(metrics.RotationLatency.WithLabelValues(metrics.PhaseListKeys))
 			keys, err := manager.ListKeys(githubOrganisation, repo.Name)
+			listKeysTimer.ObserveDuration()
 			if err != nil {
 				log.Warnf("failed to list github keys: %s", err)
+				metrics.KeysSkippedTotal.WithLabelValues(metrics.ReasonError).Inc()
 				continue
 			}
 
@@ -85,49 +103,70 @@ func New(manager *manager.Manager,
 						break
 					}
 					// Do not rotate if nothing has changed and the key is not >7 days old
-					updated, err := manager.GetLastUpdated(keyPath)
+					updated, err := store.LastUpdated(ctx, keyPath)
 					if err != nil {
-						if e, ok := err.(awserr.Error); ok && e.Code() == secretsmanager.ErrCodeResourceNotFoundException {
-							// Do not log a warning if we fail to describe because the secret does not exist.
+						if errors.Is(err, secrets.ErrNotFound) {
+							// Do not log a warning if the secret does not exist yet.
 							break
 						}
 						log.Warnf("failed to get last updated for secret: %s", err)
 						break
 					}
 					if updated.After(time.Now().AddDate(0, 0, -7)) {
+						metrics.KeysSkippedTotal.WithLabelValues(metrics.ReasonUnchanged).Inc()
 						continue Loop
 					}
 				}
 			}
 
+			if oldKey == nil {
+				metrics.KeysSkippedTotal.WithLabelValues(metrics.ReasonFresh).Inc()
+			}
+
 			// Generate a new key pair
+			generateKeyPairTimer := prometheus.NewTimer(metrics.RotationLatency.WithLabelValues(metrics.PhaseGenerateKeyPair))
 			private, public, err := manager.GenerateKeyPair(title)
+			generateKeyPairTimer.ObserveDuration()
 			if err != nil {
 				log.Warnf("failed to generate new key pair: %s", err)
+				metrics.KeysSkippedTotal.WithLabelValues(metrics.ReasonError).Inc()
 				continue
 			}
 
 			// Write the new public key to Github
-			if err = manager.CreateKey(githubOrganisation, repo.Name, repo.ReadOnly, title, public); err != nil {
+			createKeyTimer := prometheus.NewTimer(metrics.RotationLatency.WithLabelValues(metrics.PhaseCreateKey))
+			err = manager.CreateKey(githubOrganisation, repo.Name, repo.ReadOnly, title, public)
+			createKeyTimer.ObserveDuration()
+			if err != nil {
 				log.Warnf("failed to create key on github: %s", err)
+				metrics.KeysSkippedTotal.WithLabelValues(metrics.ReasonError).Inc()
 				continue
 			}
 
-			// Write the private key to Secrets manager
-			if err := manager.WriteSecret(keyPath, private); err != nil {
+			// Write the private key to the secret store
+			writeSecretTimer := prometheus.NewTimer(metrics.RotationLatency.WithLabelValues(metrics.PhaseWriteSecret))
+			err = store.Write(ctx, keyPath, private, nil)
+			writeSecretTimer.ObserveDuration()
+			if err != nil {
 				log.Warnf("failed to write secret key: %s", err)
+				metrics.KeysSkippedTotal.WithLabelValues(metrics.ReasonError).Inc()
 				continue
 			}
 
 			// Sleep before deleting old key (in case someone has just fetched the old key)
 			if oldKey != nil {
 				time.Sleep(time.Second * 1)
-				if err = manager.DeleteKey(githubOrganisation, repo.Name, *oldKey.ID); err != nil {
+				deleteOldKeyTimer := prometheus.NewTimer(metrics.RotationLatency.WithLabelValues(metrics.PhaseDeleteOldKey))
+				err = manager.DeleteKey(githubOrganisation, repo.Name, *oldKey.ID)
+				deleteOldKeyTimer.ObserveDuration()
+				if err != nil {
 					log.Warnf("failed to delete old github key: %d: %s", *oldKey.ID, err)
+					metrics.KeysSkippedTotal.WithLabelValues(metrics.ReasonError).Inc()
 					continue
 				}
 			}
 
+			metrics.KeysRotatedTotal.Inc()
 		}
 		return nil
 	}
@@ -2,25 +2,19 @@ package manager
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
-	"errors"
 	"fmt"
-	"regexp"
-	"time"
-
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
-	"github.com/aws/aws-sdk-go/service/secretsmanager"
-	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+
 	"github.com/google/go-github/v29/github"
 	"golang.org/x/crypto/ssh"
 )
 
 // RepoClient for testing purposes
+//
 //go:generate mockgen -destination=mocks/mock_repo_client.go -package=mocks github.com/telia-oss/concourse-github-lambda RepoClient
 type RepoClient interface {
 	ListKeys(ctx context.Context, owner string, repo string, opt *github.ListOptions) ([]*github.Key, *github.Response, error)
@@ -29,36 +23,26 @@ type RepoClient interface {
 }
 
 // AppsClient for testing purposes
+//
 //go:generate mockgen -destination=mocks/mock_apps_client.go -package=mocks github.com/telia-oss/concourse-github-lambda AppsClient
 type AppsClient interface {
 	ListRepos(ctx context.Context, opt *github.ListOptions) ([]*github.Repository, *github.Response, error)
 	CreateInstallationToken(ctx context.Context, id int64, opts *github.InstallationTokenOptions) (*github.InstallationToken, *github.Response, error)
 }
 
-// SecretsClient for testing purposes.
-//go:generate mockgen -destination=mocks/mock_secrets_client.go -package=mocks github.com/telia-oss/concourse-github-lambda SecretsClient
-type SecretsClient secretsmanageriface.SecretsManagerAPI
-
-// EC2Client for testing purposes.
-//go:generate mockgen -destination=mocks/mock_ec2_client.go -package=mocks github.com/telia-oss/concourse-github-lambda EC2Client
-type EC2Client ec2iface.EC2API
-
 // NewTestManager for testing purposes.
-func NewTestManager(s SecretsClient, e EC2Client, tokenService, keyService *GithubApp) *Manager {
-	return &Manager{secretsClient: s, ec2Client: e, tokenService: tokenService, keyService: keyService}
+func NewTestManager(tokenService, keyService *GithubApp) *Manager {
+	return &Manager{tokenService: tokenService, keyService: keyService}
 }
 
-// Manager handles API calls to AWS.
+// Manager handles API calls to Github.
 type Manager struct {
-	tokenService  *GithubApp
-	keyService    *GithubApp
-	secretsClient SecretsClient
-	ec2Client     EC2Client
+	tokenService *GithubApp
+	keyService   *GithubApp
 }
 
 // NewManager creates a new manager for handling rotation of Github deploy keys and access tokens.
 func NewManager(
-	sess *session.Session,
 	tokenServiceIntegrationID int64,
 	tokenServicePrivateKey string,
 	keyServiceIntegrationID int64,
@@ -75,10 +59,8 @@ func NewManager(
 	}
 
 	return &Manager{
-		tokenService:  tokenService,
-		keyService:    keyService,
-		secretsClient: secretsmanager.New(sess),
-		ec2Client:     ec2.New(sess),
+		tokenService: tokenService,
+		keyService:   keyService,
 	}, nil
 }
 
@@ -129,94 +111,87 @@ func (m *Manager) DeleteKey(repoOwner, repoName string, id int64) error {
 	return err
 }
 
-// Get the time the secret was last updated by this lambda from the secret description.
-// Note that we are not using LastChangedDate from secrets manager because in practice
-// this timestamp is updated daily by the inner workings of secrets manager.
-func (m *Manager) GetLastUpdated(name string) (*time.Time, error) {
-	out, err := m.secretsClient.DescribeSecret(&secretsmanager.DescribeSecretInput{
-		SecretId: aws.String(name),
-	})
-	if err != nil {
-		return nil, err
-	}
+// KeyType selects the algorithm GenerateKeyPair uses to produce a deploy key pair.
+type KeyType int
 
-	re := regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z`)
-	ds := re.FindString(aws.StringValue(out.Description))
+const (
+	// KeyTypeEd25519 generates a pure Go Ed25519 key pair. This is the default: the keys are
+	// smaller than RSA and are preferred by Github today.
+	KeyTypeEd25519 KeyType = iota
+	// KeyTypeRSA generates an RSA-2048 key pair, kept for deploy targets that do not yet
+	// support Ed25519.
+	KeyTypeRSA
+)
 
-	if ds == "" {
-		return nil, fmt.Errorf("failed to find timestamp in description: %s", aws.StringValue(out.Description))
-	}
+type keyPairOptions struct {
+	keyType KeyType
+}
 
-	t, err := time.Parse(time.RFC3339, ds)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse timestamp: %s", err)
-	}
+// KeyPairOption configures GenerateKeyPair.
+type KeyPairOption func(*keyPairOptions)
 
-	return &t, nil
+// WithKeyType selects the key algorithm GenerateKeyPair uses. The default is KeyTypeEd25519.
+func WithKeyType(t KeyType) KeyPairOption {
+	return func(o *keyPairOptions) { o.keyType = t }
 }
 
-// Write a secret to secrets manager.
-func (m *Manager) WriteSecret(name, secret string) error {
-	var err error
-	timestamp := time.Now().UTC().Format(time.RFC3339)
-
-	_, err = m.secretsClient.CreateSecret(&secretsmanager.CreateSecretInput{
-		Name:        aws.String(name),
-		Description: aws.String(fmt.Sprintf("Github credentials for Concourse. Last updated: %s", timestamp)),
-	})
-	if err != nil {
-		e, ok := err.(awserr.Error)
-		if !ok {
-			return fmt.Errorf("failed to convert error: %s", err)
-		}
-		if e.Code() != secretsmanager.ErrCodeResourceExistsException {
-			return err
-		}
+// Generate a key pair for the deploy key.
+func (m *Manager) GenerateKeyPair(title string, opts ...KeyPairOption) (privateKey string, publicKey string, err error) {
+	options := &keyPairOptions{keyType: KeyTypeEd25519}
+	for _, opt := range opts {
+		opt(options)
 	}
 
-	_, err = m.secretsClient.UpdateSecret(&secretsmanager.UpdateSecretInput{
-		Description:  aws.String(fmt.Sprintf("Github credentials for Concourse. Last updated: %s", timestamp)),
-		SecretId:     aws.String(name),
-		SecretString: aws.String(secret),
-	})
-	return err
+	switch options.keyType {
+	case KeyTypeRSA:
+		return generateRSAKeyPair()
+	default:
+		return generateEd25519KeyPair()
+	}
 }
 
-// Generate a key pair for the deploy key.
-func (m *Manager) GenerateKeyPair(title string) (privateKey string, publicKey string, err error) {
-	// Have EC2 Generate a new private key
-	res, err := m.ec2Client.CreateKeyPair(&ec2.CreateKeyPairInput{
-		KeyName: aws.String(title),
-	})
+// generateEd25519KeyPair generates an Ed25519 key pair entirely in-process, rather than
+// round-tripping through the EC2 API purely to reuse its RSA key generator.
+func generateEd25519KeyPair() (privateKey string, publicKey string, err error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	block, err := ssh.MarshalPrivateKey(private, "")
 	if err != nil {
 		return "", "", err
 	}
+	privateKey = string(pem.EncodeToMemory(block))
 
-	// Remember to clean up temporary key when done
-	defer func() {
-		// TODO: Don't discard error, handle it somehow.
-		m.ec2Client.DeleteKeyPair(&ec2.DeleteKeyPairInput{
-			KeyName: aws.String(title),
-		})
-	}()
-	privateKey = aws.StringValue(res.KeyMaterial)
-
-	// Parse the private key
-	block, _ := pem.Decode([]byte(privateKey))
-	if block == nil {
-		return "", "", errors.New("failed to decode private key")
+	sshPublic, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return "", "", err
 	}
+	publicKey = string(ssh.MarshalAuthorizedKey(sshPublic))
 
-	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	return privateKey, publicKey, nil
+}
+
+// generateRSAKeyPair generates an RSA-2048 key pair for deploy targets that do not yet
+// support Ed25519 deploy keys.
+func generateRSAKeyPair() (privateKey string, publicKey string, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
 		return "", "", err
 	}
 
-	public, err := ssh.NewPublicKey(&key.PublicKey)
+	block := &pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}
+	privateKey = string(pem.EncodeToMemory(block))
+
+	sshPublic, err := ssh.NewPublicKey(&key.PublicKey)
 	if err != nil {
 		return "", "", err
 	}
-	publicKey = string(ssh.MarshalAuthorizedKey(public))
+	publicKey = string(ssh.MarshalAuthorizedKey(sshPublic))
 
 	return privateKey, publicKey, nil
 }
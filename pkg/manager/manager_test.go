@@ -0,0 +1,49 @@
+package manager
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerateKeyPairEd25519(t *testing.T) {
+	m := &Manager{}
+
+	private, public, err := m.GenerateKeyPair("test-key")
+	if err != nil {
+		t.Fatalf("expected no error generating key pair, got %v", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey([]byte(private))
+	if err != nil {
+		t.Fatalf("expected generated private key to parse, got %v", err)
+	}
+	if got, want := signer.PublicKey().Type(), ssh.KeyAlgoED25519; got != want {
+		t.Fatalf("expected key type %q, got %q", want, got)
+	}
+
+	if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(public)); err != nil {
+		t.Fatalf("expected generated public key to parse, got %v", err)
+	}
+}
+
+func TestGenerateKeyPairRSA(t *testing.T) {
+	m := &Manager{}
+
+	private, public, err := m.GenerateKeyPair("test-key", WithKeyType(KeyTypeRSA))
+	if err != nil {
+		t.Fatalf("expected no error generating key pair, got %v", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey([]byte(private))
+	if err != nil {
+		t.Fatalf("expected generated private key to parse, got %v", err)
+	}
+	if got, want := signer.PublicKey().Type(), ssh.KeyAlgoRSA; got != want {
+		t.Fatalf("expected key type %q, got %q", want, got)
+	}
+
+	if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(public)); err != nil {
+		t.Fatalf("expected generated public key to parse, got %v", err)
+	}
+}
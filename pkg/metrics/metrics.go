@@ -0,0 +1,102 @@
+// Package metrics exposes Prometheus instrumentation for the rotation lambda.
+//
+// Phase timings and rotation outcomes are recorded against a private registry rather than
+// the global default, since a Lambda invocation should only ever publish its own metrics.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/sirupsen/logrus"
+)
+
+// Phases of a rotation, used as the "phase" label on RotationLatency.
+const (
+	PhaseListRepos         = "list_repos"
+	PhaseCreateAccessToken = "create_access_token"
+	PhaseWriteToken        = "write_token"
+	PhaseListKeys          = "list_keys"
+	PhaseGenerateKeyPair   = "generate_keypair"
+	PhaseCreateKey         = "create_key"
+	PhaseWriteSecret       = "write_secret"
+	PhaseDeleteOldKey      = "delete_old_key"
+)
+
+// Reasons a key rotation was skipped, used as the "reason" label on KeysSkippedTotal.
+const (
+	ReasonFresh     = "fresh"     // Key is new; there was nothing to skip.
+	ReasonUnchanged = "unchanged" // Existing key has not changed and is not yet due rotation.
+	ReasonError     = "error"     // A phase of the rotation failed.
+)
+
+// Registry is the private registry that all lambda metrics are registered against.
+var Registry = prometheus.NewRegistry()
+
+// RotationLatency records how long each phase of a key/token rotation takes.
+var RotationLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name: "github_lambda_rotation_latency_seconds",
+	Help: "Latency of each phase of the deploy key and access token rotation, in seconds.",
+}, []string{"phase"})
+
+// KeysRotatedTotal counts the number of deploy keys successfully rotated.
+var KeysRotatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "github_lambda_keys_rotated_total",
+	Help: "Total number of deploy keys rotated.",
+})
+
+// KeysSkippedTotal counts deploy keys by reason. ReasonUnchanged and ReasonError count keys
+// that were not rotated; ReasonFresh counts repositories with no existing key, for which a new
+// key is still created.
+var KeysSkippedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "github_lambda_keys_skipped_total",
+	Help: "Total number of deploy keys skipped, by reason.",
+}, []string{"reason"})
+
+// ReposProcessedTotal counts the number of repositories considered for key rotation.
+var ReposProcessedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "github_lambda_repos_processed_total",
+	Help: "Total number of repositories processed.",
+})
+
+func init() {
+	Registry.MustRegister(RotationLatency, KeysRotatedTotal, KeysSkippedTotal, ReposProcessedTotal)
+}
+
+// Serve exposes the registry on addr for scraping, for local or SAM runs where the process
+// stays alive long enough for Prometheus to pull metrics.
+func Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+	return http.ListenAndServe(addr, mux)
+}
+
+// PushGateway pushes the registry to the Pushgateway at url under job, repeating every
+// interval until stop is closed. A Lambda invocation's process exits as soon as the handler
+// returns, so there is no window in which Prometheus could otherwise scrape it directly.
+// Push failures (e.g. the Pushgateway being unreachable) are logged rather than returned,
+// since the caller has already moved on to the next tick or is shutting down.
+func PushGateway(url, job string, interval time.Duration, stop <-chan struct{}, logger *logrus.Logger) {
+	pusher := push.New(url, job).Gatherer(Registry)
+	log := logger.WithField("pushgateway_url", url)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			if err := pusher.Push(); err != nil {
+				log.Warnf("failed to push metrics to pushgateway: %s", err)
+			}
+			return
+		case <-ticker.C:
+			if err := pusher.Push(); err != nil {
+				log.Warnf("failed to push metrics to pushgateway: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Package githubapp implements repo.Lister by enumerating every repository a Github App is
+// installed on, removing the need to separately maintain a list of repos elsewhere.
+package githubapp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/google/go-github/v29/github"
+	"github.com/telia-oss/concourse-github-lambda/pkg/repo"
+)
+
+var _ repo.Lister = new(Lister)
+
+// AppsClient for testing purposes.
+//
+//go:generate mockgen -destination=mocks/mock_apps_client.go -package=mocks github.com/telia-oss/concourse-github-lambda/pkg/repo/githubapp AppsClient
+type AppsClient interface {
+	ListRepos(ctx context.Context, opt *github.ListOptions) ([]*github.Repository, *github.Response, error)
+}
+
+// Option configures a Lister.
+type Option func(*Lister)
+
+// WithTopic restricts the listed repositories to those tagged with topic.
+func WithTopic(topic string) Option {
+	return func(l *Lister) { l.topic = topic }
+}
+
+// WithNameRegex restricts the listed repositories to those whose name matches re.
+func WithNameRegex(re *regexp.Regexp) Option {
+	return func(l *Lister) { l.nameRegex = re }
+}
+
+// Lister lists every repository the Github App installation behind client can see.
+type Lister struct {
+	client    AppsClient
+	topic     string
+	nameRegex *regexp.Regexp
+}
+
+// New creates a Lister using client to enumerate the installation's repositories.
+func New(client AppsClient, opts ...Option) *Lister {
+	l := &Lister{client: client}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// List returns every non-archived repository the installation can see, matching the topic
+// and/or name regex filters if configured.
+func (l *Lister) List() ([]*repo.Repo, error) {
+	var repos []*repo.Repo
+
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		ghRepos, resp, err := l.client.ListRepos(context.TODO(), opt)
+		if err != nil {
+			return nil, fmt.Errorf("listing repos for github app installation: %w", err)
+		}
+
+		for _, ghRepo := range ghRepos {
+			if ghRepo.GetArchived() {
+				continue
+			}
+			if l.topic != "" && !hasTopic(ghRepo, l.topic) {
+				continue
+			}
+			if l.nameRegex != nil && !l.nameRegex.MatchString(ghRepo.GetName()) {
+				continue
+			}
+
+			repos = append(repos, &repo.Repo{
+				Name:     ghRepo.GetName(),
+				ReadOnly: !ghRepo.GetPermissions()["push"],
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return repos, nil
+}
+
+func hasTopic(ghRepo *github.Repository, topic string) bool {
+	for _, t := range ghRepo.Topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
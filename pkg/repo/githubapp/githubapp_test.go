@@ -0,0 +1,95 @@
+package githubapp
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-github/v29/github"
+)
+
+// fakeAppsClient serves ListRepos from a fixed, pre-paginated set of pages.
+type fakeAppsClient struct {
+	pages [][]*github.Repository
+}
+
+func (f *fakeAppsClient) ListRepos(ctx context.Context, opt *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	page := opt.Page
+	repos := f.pages[page]
+
+	resp := &github.Response{}
+	if page+1 < len(f.pages) {
+		resp.NextPage = page + 1
+	}
+
+	return repos, resp, nil
+}
+
+func repoPtr(name string, archived bool, push bool, topics ...string) *github.Repository {
+	return &github.Repository{
+		Name:     github.String(name),
+		Archived: github.Bool(archived),
+		Topics:   topics,
+		Permissions: &map[string]bool{
+			"push": push,
+		},
+	}
+}
+
+func TestListPaginatesAndMapsRepos(t *testing.T) {
+	client := &fakeAppsClient{
+		pages: [][]*github.Repository{
+			{repoPtr("repo-a", false, true), repoPtr("repo-b", true, true)},
+			{repoPtr("repo-c", false, false)},
+		},
+	}
+
+	repos, err := New(client).List()
+	if err != nil {
+		t.Fatalf("expected no error listing repos, got %v", err)
+	}
+
+	if len(repos) != 2 {
+		t.Fatalf("expected 2 repos (archived repo skipped), got %d: %v", len(repos), repos)
+	}
+	if repos[0].Name != "repo-a" || repos[0].ReadOnly {
+		t.Fatalf("expected repo-a with push access, got %+v", repos[0])
+	}
+	if repos[1].Name != "repo-c" || !repos[1].ReadOnly {
+		t.Fatalf("expected repo-c read-only, got %+v", repos[1])
+	}
+}
+
+func TestListWithTopic(t *testing.T) {
+	client := &fakeAppsClient{
+		pages: [][]*github.Repository{
+			{repoPtr("repo-a", false, true, "deploy"), repoPtr("repo-b", false, true, "other")},
+		},
+	}
+
+	repos, err := New(client, WithTopic("deploy")).List()
+	if err != nil {
+		t.Fatalf("expected no error listing repos, got %v", err)
+	}
+
+	if len(repos) != 1 || repos[0].Name != "repo-a" {
+		t.Fatalf("expected only repo-a to match the topic filter, got %v", repos)
+	}
+}
+
+func TestListWithNameRegex(t *testing.T) {
+	client := &fakeAppsClient{
+		pages: [][]*github.Repository{
+			{repoPtr("service-a", false, true), repoPtr("library-b", false, true)},
+		},
+	}
+
+	repos, err := New(client, WithNameRegex(regexp.MustCompile(`^service-`))).List()
+	if err != nil {
+		t.Fatalf("expected no error listing repos, got %v", err)
+	}
+
+	if len(repos) != 1 || repos[0].Name != "service-a" {
+		t.Fatalf("expected only service-a to match the name regex, got %v", repos)
+	}
+}
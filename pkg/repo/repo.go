@@ -10,3 +10,8 @@ type Repo struct {
 func (r *Repo) String() string {
 	return fmt.Sprintf("Name: %q, ReadOnly: %t", r.Name, r.ReadOnly)
 }
+
+// Lister lists the repositories that deploy keys and access tokens should be managed for.
+type Lister interface {
+	List() ([]*Repo, error)
+}
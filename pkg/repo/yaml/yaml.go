@@ -0,0 +1,52 @@
+// Package yaml implements repo.Lister by reading a static list of repositories from a YAML
+// file, for local testing without a DynamoDB table or a Github App installation.
+package yaml
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/telia-oss/concourse-github-lambda/pkg/repo"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var _ repo.Lister = new(Lister)
+
+type fileRepo struct {
+	Name     string `yaml:"name"`
+	ReadOnly bool   `yaml:"read_only"`
+}
+
+// Lister lists the repositories defined in a YAML file of the form:
+//
+//   - name: some-repo
+//     read_only: false
+//   - name: another-repo
+//     read_only: true
+type Lister struct {
+	path string
+}
+
+// New creates a Lister reading repositories from the YAML file at path.
+func New(path string) *Lister {
+	return &Lister{path: path}
+}
+
+func (l *Lister) List() ([]*repo.Repo, error) {
+	data, err := ioutil.ReadFile(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading repos file: %w", err)
+	}
+
+	var fileRepos []fileRepo
+	if err := yaml.Unmarshal(data, &fileRepos); err != nil {
+		return nil, fmt.Errorf("parsing repos file: %w", err)
+	}
+
+	repos := make([]*repo.Repo, 0, len(fileRepos))
+	for _, fr := range fileRepos {
+		repos = append(repos, &repo.Repo{Name: fr.Name, ReadOnly: fr.ReadOnly})
+	}
+
+	return repos, nil
+}
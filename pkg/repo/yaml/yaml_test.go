@@ -0,0 +1,60 @@
+package yaml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/telia-oss/concourse-github-lambda/pkg/repo"
+)
+
+func writeFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "repos.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func TestListParsesRepos(t *testing.T) {
+	path := writeFile(t, `
+- name: some-repo
+  read_only: false
+- name: another-repo
+  read_only: true
+`)
+
+	repos, err := New(path).List()
+	if err != nil {
+		t.Fatalf("expected no error listing repos, got %v", err)
+	}
+
+	want := []*repo.Repo{
+		{Name: "some-repo", ReadOnly: false},
+		{Name: "another-repo", ReadOnly: true},
+	}
+	if len(repos) != len(want) {
+		t.Fatalf("expected %d repos, got %d: %v", len(want), len(repos), repos)
+	}
+	for i := range want {
+		if *repos[i] != *want[i] {
+			t.Fatalf("expected repo %+v, got %+v", want[i], repos[i])
+		}
+	}
+}
+
+func TestListMalformedYAML(t *testing.T) {
+	path := writeFile(t, `not: [valid, yaml`)
+
+	if _, err := New(path).List(); err == nil {
+		t.Fatal("expected an error parsing malformed YAML, got nil")
+	}
+}
+
+func TestListMissingFile(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.yaml")).List(); err == nil {
+		t.Fatal("expected an error reading a missing file, got nil")
+	}
+}
@@ -0,0 +1,110 @@
+// Package awssm implements secrets.Store on top of AWS Secrets Manager.
+package awssm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets"
+)
+
+var _ secrets.Store = new(Store)
+
+// Client for testing purposes.
+//
+//go:generate mockgen -destination=mocks/mock_client.go -package=mocks github.com/telia-oss/concourse-github-lambda/pkg/secrets/awssm Client
+type Client secretsmanageriface.SecretsManagerAPI
+
+// lastUpdatedRegexp matches the timestamp this package embeds into a secret's description,
+// since we cannot rely on Secrets Manager's own LastChangedDate: in practice that timestamp
+// is updated daily by the inner workings of secrets manager.
+var lastUpdatedRegexp = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z`)
+
+// Store writes secrets to AWS Secrets Manager.
+type Store struct {
+	client Client
+}
+
+// New creates a Store backed by Secrets Manager in sess.
+func New(sess *session.Session) *Store {
+	return &Store{client: secretsmanager.New(sess)}
+}
+
+// NewTestStore for testing purposes.
+func NewTestStore(c Client) *Store {
+	return &Store{client: c}
+}
+
+// Write a secret to secrets manager. meta is ignored: the last-updated timestamp is embedded
+// in the secret's description instead, since Secrets Manager has no custom metadata field.
+func (s *Store) Write(ctx context.Context, path, value string, meta map[string]string) error {
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+
+	_, err := s.client.CreateSecretWithContext(ctx, &secretsmanager.CreateSecretInput{
+		Name:        aws.String(path),
+		Description: aws.String(fmt.Sprintf("Github credentials for Concourse. Last updated: %s", timestamp)),
+	})
+	if err != nil {
+		e, ok := err.(awserr.Error)
+		if !ok {
+			return fmt.Errorf("failed to convert error: %s", err)
+		}
+		if e.Code() != secretsmanager.ErrCodeResourceExistsException {
+			return err
+		}
+	}
+
+	_, err = s.client.UpdateSecretWithContext(ctx, &secretsmanager.UpdateSecretInput{
+		Description:  aws.String(fmt.Sprintf("Github credentials for Concourse. Last updated: %s", timestamp)),
+		SecretId:     aws.String(path),
+		SecretString: aws.String(value),
+	})
+	return err
+}
+
+// LastUpdated returns the time this lambda last wrote to path, parsed from the secret's
+// description.
+func (s *Store) LastUpdated(ctx context.Context, path string) (time.Time, error) {
+	out, err := s.client.DescribeSecretWithContext(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(path),
+	})
+	if err != nil {
+		if e, ok := err.(awserr.Error); ok && e.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			return time.Time{}, secrets.ErrNotFound
+		}
+		return time.Time{}, err
+	}
+
+	ds := lastUpdatedRegexp.FindString(aws.StringValue(out.Description))
+	if ds == "" {
+		return time.Time{}, fmt.Errorf("failed to find timestamp in description: %s", aws.StringValue(out.Description))
+	}
+
+	t, err := time.Parse(time.RFC3339, ds)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse timestamp: %s", err)
+	}
+
+	return t, nil
+}
+
+// Read returns the value currently stored at path.
+func (s *Store) Read(ctx context.Context, path string) (string, error) {
+	out, err := s.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(path),
+	})
+	if err != nil {
+		if e, ok := err.(awserr.Error); ok && e.Code() == secretsmanager.ErrCodeResourceNotFoundException {
+			return "", secrets.ErrNotFound
+		}
+		return "", err
+	}
+	return aws.StringValue(out.SecretString), nil
+}
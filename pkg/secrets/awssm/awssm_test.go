@@ -0,0 +1,121 @@
+package awssm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets"
+)
+
+// fakeClient stubs the handful of SecretsManagerAPI methods awssm.Store calls; embedding the
+// interface satisfies the rest without implementing them.
+type fakeClient struct {
+	secretsmanageriface.SecretsManagerAPI
+
+	createErr   error
+	updateErr   error
+	describeOut *secretsmanager.DescribeSecretOutput
+	describeErr error
+	getValueOut *secretsmanager.GetSecretValueOutput
+	getValueErr error
+}
+
+func (f *fakeClient) CreateSecretWithContext(ctx aws.Context, in *secretsmanager.CreateSecretInput, opts ...request.Option) (*secretsmanager.CreateSecretOutput, error) {
+	return &secretsmanager.CreateSecretOutput{}, f.createErr
+}
+
+func (f *fakeClient) UpdateSecretWithContext(ctx aws.Context, in *secretsmanager.UpdateSecretInput, opts ...request.Option) (*secretsmanager.UpdateSecretOutput, error) {
+	return &secretsmanager.UpdateSecretOutput{}, f.updateErr
+}
+
+func (f *fakeClient) DescribeSecretWithContext(ctx aws.Context, in *secretsmanager.DescribeSecretInput, opts ...request.Option) (*secretsmanager.DescribeSecretOutput, error) {
+	return f.describeOut, f.describeErr
+}
+
+func (f *fakeClient) GetSecretValueWithContext(ctx aws.Context, in *secretsmanager.GetSecretValueInput, opts ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+	return f.getValueOut, f.getValueErr
+}
+
+func TestStoreWrite(t *testing.T) {
+	client := &fakeClient{}
+	store := NewTestStore(client)
+
+	if err := store.Write(context.Background(), "some/path", "some-value", nil); err != nil {
+		t.Fatalf("expected no error writing secret, got %v", err)
+	}
+}
+
+func TestStoreWriteSecretAlreadyExists(t *testing.T) {
+	client := &fakeClient{
+		createErr: awserr.New(secretsmanager.ErrCodeResourceExistsException, "already exists", nil),
+	}
+	store := NewTestStore(client)
+
+	if err := store.Write(context.Background(), "some/path", "some-value", nil); err != nil {
+		t.Fatalf("expected no error writing secret that already exists, got %v", err)
+	}
+}
+
+func TestStoreLastUpdated(t *testing.T) {
+	client := &fakeClient{
+		describeOut: &secretsmanager.DescribeSecretOutput{
+			Description: aws.String("Github credentials for Concourse. Last updated: 2026-07-28T00:00:00Z"),
+		},
+	}
+	store := NewTestStore(client)
+
+	got, err := store.LastUpdated(context.Background(), "some/path")
+	if err != nil {
+		t.Fatalf("expected no error getting last updated, got %v", err)
+	}
+
+	want, _ := time.Parse(time.RFC3339, "2026-07-28T00:00:00Z")
+	if !got.Equal(want) {
+		t.Fatalf("expected last updated %v, got %v", want, got)
+	}
+}
+
+func TestStoreLastUpdatedNotFound(t *testing.T) {
+	client := &fakeClient{
+		describeErr: awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil),
+	}
+	store := NewTestStore(client)
+
+	if _, err := store.LastUpdated(context.Background(), "some/path"); err != secrets.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStoreRead(t *testing.T) {
+	client := &fakeClient{
+		getValueOut: &secretsmanager.GetSecretValueOutput{
+			SecretString: aws.String("some-value"),
+		},
+	}
+	store := NewTestStore(client)
+
+	got, err := store.Read(context.Background(), "some/path")
+	if err != nil {
+		t.Fatalf("expected no error reading secret, got %v", err)
+	}
+	if got != "some-value" {
+		t.Fatalf("expected secret value %q, got %q", "some-value", got)
+	}
+}
+
+func TestStoreReadNotFound(t *testing.T) {
+	client := &fakeClient{
+		getValueErr: awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil),
+	}
+	store := NewTestStore(client)
+
+	if _, err := store.Read(context.Background(), "some/path"); err != secrets.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+// Package factory selects a secrets.Store implementation at runtime via the SECRET_BACKEND
+// environment variable, so operators running Concourse outside AWS can reuse this lambda's
+// rotation logic as a container or cron job, pointed at whichever secret store they already run.
+package factory
+
+import (
+	"fmt"
+	"os"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/1Password/connect-sdk-go/connect"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets/awssm"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets/onepassword"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets/vault"
+)
+
+// Backend identifies a supported secrets.Store implementation.
+type Backend string
+
+// Supported backends, selected via the SECRET_BACKEND environment variable.
+const (
+	BackendAWS       Backend = "aws"
+	BackendVault     Backend = "vault"
+	Backend1Password Backend = "1password"
+)
+
+// NewFromEnv constructs the secrets.Store selected by the SECRET_BACKEND environment
+// variable (aws|vault|1password, defaulting to aws), reading each backend's own
+// configuration from the environment too.
+func NewFromEnv(sess *session.Session) (secrets.Store, error) {
+	switch Backend(os.Getenv("SECRET_BACKEND")) {
+	case "", BackendAWS:
+		return awssm.New(sess), nil
+	case BackendVault:
+		client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+		if err != nil {
+			return nil, fmt.Errorf("creating vault client: %w", err)
+		}
+		mount := os.Getenv("VAULT_MOUNT")
+		if mount == "" {
+			mount = "secret"
+		}
+		return vault.New(client, mount), nil
+	case Backend1Password:
+		client := connect.NewClient(os.Getenv("OP_CONNECT_HOST"), os.Getenv("OP_CONNECT_TOKEN"))
+		return onepassword.New(client, os.Getenv("OP_VAULT_ID")), nil
+	default:
+		return nil, fmt.Errorf("unknown secret backend: %q", os.Getenv("SECRET_BACKEND"))
+	}
+}
@@ -0,0 +1,123 @@
+// Package onepassword implements secrets.Store on top of 1Password Connect.
+package onepassword
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/1Password/connect-sdk-go/connect"
+	"github.com/1Password/connect-sdk-go/onepassword"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets"
+)
+
+var _ secrets.Store = new(Store)
+
+const (
+	passwordFieldLabel    = "password"
+	lastUpdatedFieldLabel = "lastUpdated"
+)
+
+// Store writes secrets as items in a 1Password Connect vault.
+type Store struct {
+	client  connect.Client
+	vaultID string
+}
+
+// New creates a Store writing items into vaultID via client.
+func New(client connect.Client, vaultID string) *Store {
+	return &Store{client: client, vaultID: vaultID}
+}
+
+// Write stores value as the password field of an item titled path, with the last-updated
+// time and meta stored as additional custom fields. An existing item is updated in place.
+func (s *Store) Write(ctx context.Context, path, value string, meta map[string]string) error {
+	item, err := s.findItem(path)
+	if err != nil {
+		return fmt.Errorf("finding existing 1password item: %w", err)
+	}
+
+	fields := []*onepassword.ItemField{
+		{Label: passwordFieldLabel, Purpose: "PASSWORD", Value: value},
+		{Label: lastUpdatedFieldLabel, Value: time.Now().UTC().Format(time.RFC3339)},
+	}
+	for k, v := range meta {
+		fields = append(fields, &onepassword.ItemField{Label: k, Value: v})
+	}
+
+	if item == nil {
+		item = &onepassword.Item{
+			Title:    path,
+			Category: onepassword.Login,
+			Vault:    onepassword.ItemVault{ID: s.vaultID},
+			Fields:   fields,
+		}
+		_, err = s.client.CreateItem(item, s.vaultID)
+		return err
+	}
+
+	item.Fields = fields
+	_, err = s.client.UpdateItem(item, s.vaultID)
+	return err
+}
+
+// LastUpdated returns the time this lambda last wrote to path, read from the lastUpdated
+// custom field.
+func (s *Store) LastUpdated(ctx context.Context, path string) (time.Time, error) {
+	item, err := s.findItem(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("finding 1password item: %w", err)
+	}
+	if item == nil {
+		return time.Time{}, secrets.ErrNotFound
+	}
+
+	for _, field := range item.Fields {
+		if field.Label == lastUpdatedFieldLabel {
+			t, err := time.Parse(time.RFC3339, field.Value)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
+			}
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("no %s field found for path: %s", lastUpdatedFieldLabel, path)
+}
+
+// Read returns the value currently stored at path.
+func (s *Store) Read(ctx context.Context, path string) (string, error) {
+	item, err := s.findItem(path)
+	if err != nil {
+		return "", fmt.Errorf("finding 1password item: %w", err)
+	}
+	if item == nil {
+		return "", secrets.ErrNotFound
+	}
+
+	for _, field := range item.Fields {
+		if field.Label == passwordFieldLabel {
+			return field.Value, nil
+		}
+	}
+
+	return "", fmt.Errorf("no %s field found for path: %s", passwordFieldLabel, path)
+}
+
+// findItem looks up the item titled path, returning nil if it does not exist yet.
+func (s *Store) findItem(path string) (*onepassword.Item, error) {
+	item, err := s.client.GetItemByTitle(path, s.vaultID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// isNotFound reports whether err represents a 1Password Connect "item not found" response.
+func isNotFound(err error) bool {
+	opErr, ok := err.(*onepassword.Error)
+	return ok && opErr.StatusCode == 404
+}
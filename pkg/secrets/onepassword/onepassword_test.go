@@ -0,0 +1,89 @@
+package onepassword
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/1Password/connect-sdk-go/connect"
+	"github.com/1Password/connect-sdk-go/onepassword"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets"
+)
+
+// fakeClient stubs the handful of connect.Client methods Store calls; embedding the interface
+// satisfies the rest without implementing them.
+type fakeClient struct {
+	connect.Client
+
+	item *onepassword.Item
+}
+
+func (f *fakeClient) GetItemByTitle(title, vaultUUID string) (*onepassword.Item, error) {
+	if f.item == nil {
+		return nil, &onepassword.Error{StatusCode: 404, Message: "not found"}
+	}
+	return f.item, nil
+}
+
+func (f *fakeClient) CreateItem(item *onepassword.Item, vaultUUID string) (*onepassword.Item, error) {
+	f.item = item
+	return item, nil
+}
+
+func (f *fakeClient) UpdateItem(item *onepassword.Item, vaultUUID string) (*onepassword.Item, error) {
+	f.item = item
+	return item, nil
+}
+
+func TestStoreWriteReadRoundTrip(t *testing.T) {
+	client := &fakeClient{}
+	store := New(client, "some-vault-id")
+
+	if err := store.Write(context.Background(), "some/path", "some-value", nil); err != nil {
+		t.Fatalf("expected no error writing secret, got %v", err)
+	}
+
+	got, err := store.Read(context.Background(), "some/path")
+	if err != nil {
+		t.Fatalf("expected no error reading secret, got %v", err)
+	}
+	if got != "some-value" {
+		t.Fatalf("expected secret value %q, got %q", "some-value", got)
+	}
+}
+
+func TestStoreLastUpdated(t *testing.T) {
+	client := &fakeClient{}
+	store := New(client, "some-vault-id")
+
+	before := time.Now().UTC()
+	if err := store.Write(context.Background(), "some/path", "some-value", nil); err != nil {
+		t.Fatalf("expected no error writing secret, got %v", err)
+	}
+
+	got, err := store.LastUpdated(context.Background(), "some/path")
+	if err != nil {
+		t.Fatalf("expected no error getting last updated, got %v", err)
+	}
+	if got.Before(before.Add(-time.Minute)) {
+		t.Fatalf("expected last updated to be recent, got %v", got)
+	}
+}
+
+func TestStoreReadNotFound(t *testing.T) {
+	client := &fakeClient{}
+	store := New(client, "some-vault-id")
+
+	if _, err := store.Read(context.Background(), "some/path"); err != secrets.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStoreLastUpdatedNotFound(t *testing.T) {
+	client := &fakeClient{}
+	store := New(client, "some-vault-id")
+
+	if _, err := store.LastUpdated(context.Background(), "some/path"); err != secrets.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+// Package secrets defines the storage interface used to persist rotated Github credentials.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by LastUpdated and Read when path has never been written to.
+var ErrNotFound = errors.New("secret not found")
+
+// Store persists and retrieves the Github credentials rotated by this lambda.
+//
+//go:generate mockgen -destination=mocks/mock_store.go -package=mocks github.com/telia-oss/concourse-github-lambda/pkg/secrets Store
+type Store interface {
+	// Write stores value at path, recording meta alongside it where the backend supports
+	// custom metadata; backends without such support ignore meta.
+	Write(ctx context.Context, path, value string, meta map[string]string) error
+
+	// LastUpdated returns the time this lambda last wrote to path.
+	LastUpdated(ctx context.Context, path string) (time.Time, error)
+
+	// Read returns the value currently stored at path.
+	Read(ctx context.Context, path string) (string, error)
+}
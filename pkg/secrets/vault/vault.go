@@ -0,0 +1,99 @@
+// Package vault implements secrets.Store on top of a HashiCorp Vault KV version 2 secrets engine.
+package vault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets"
+)
+
+var _ secrets.Store = new(Store)
+
+const lastUpdatedMetadataKey = "last_updated"
+
+// Store writes secrets to a KV v2 mount in Vault.
+type Store struct {
+	client *vaultapi.Client
+	mount  string
+}
+
+// New creates a Store writing secrets under mount (the KV v2 mount path, e.g. "secret") using client.
+func New(client *vaultapi.Client, mount string) *Store {
+	return &Store{client: client, mount: mount}
+}
+
+// Write a secret to the KV v2 mount, recording the last-updated time and meta as custom
+// metadata rather than stuffing it into the value, since KV v2 supports this natively.
+func (s *Store) Write(ctx context.Context, path, value string, meta map[string]string) error {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{"value": value},
+	}
+	if _, err := s.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/data/%s", s.mount, path), data); err != nil {
+		return fmt.Errorf("writing secret to vault: %w", err)
+	}
+
+	customMetadata := map[string]interface{}{lastUpdatedMetadataKey: time.Now().UTC().Format(time.RFC3339)}
+	for k, v := range meta {
+		customMetadata[k] = v
+	}
+	metadata := map[string]interface{}{"custom_metadata": customMetadata}
+	if _, err := s.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/metadata/%s", s.mount, path), metadata); err != nil {
+		return fmt.Errorf("writing custom metadata to vault: %w", err)
+	}
+
+	return nil
+}
+
+// LastUpdated returns the time this lambda last wrote to path, read from custom_metadata.
+func (s *Store) LastUpdated(ctx context.Context, path string) (time.Time, error) {
+	secret, err := s.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/metadata/%s", s.mount, path))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading secret metadata from vault: %w", err)
+	}
+	if secret == nil {
+		return time.Time{}, secrets.ErrNotFound
+	}
+
+	customMetadata, ok := secret.Data["custom_metadata"].(map[string]interface{})
+	if !ok {
+		return time.Time{}, fmt.Errorf("no custom_metadata found at path: %s", path)
+	}
+
+	ds, ok := customMetadata[lastUpdatedMetadataKey].(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("no %s custom metadata found at path: %s", lastUpdatedMetadataKey, path)
+	}
+
+	t, err := time.Parse(time.RFC3339, ds)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
+	}
+
+	return t, nil
+}
+
+// Read returns the value currently stored at path.
+func (s *Store) Read(ctx context.Context, path string) (string, error) {
+	secret, err := s.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/data/%s", s.mount, path))
+	if err != nil {
+		return "", fmt.Errorf("reading secret from vault: %w", err)
+	}
+	if secret == nil {
+		return "", secrets.ErrNotFound
+	}
+
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("no data found at path: %s", path)
+	}
+
+	value, ok := data["value"].(string)
+	if !ok {
+		return "", fmt.Errorf("no value found at path: %s", path)
+	}
+
+	return value, nil
+}
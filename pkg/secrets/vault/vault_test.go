@@ -0,0 +1,140 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+
+	"github.com/telia-oss/concourse-github-lambda/pkg/secrets"
+)
+
+// fakeVault is a minimal in-memory stand-in for a Vault KV v2 mount, just enough of the HTTP
+// API for Store to talk to via a real *vaultapi.Client.
+type fakeVault struct {
+	value          string
+	lastUpdated    string
+	hasBeenWritten bool
+}
+
+func (f *fakeVault) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/secret/data/some/path", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			f.hasBeenWritten = true
+			var body struct {
+				Data map[string]interface{} `json:"data"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			f.value, _ = body.Data["value"].(string)
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"version": 1}})
+		case http.MethodGet:
+			if !f.hasBeenWritten {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"data": map[string]interface{}{"value": f.value}},
+			})
+		}
+	})
+
+	mux.HandleFunc("/v1/secret/metadata/some/path", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			var body struct {
+				CustomMetadata map[string]interface{} `json:"custom_metadata"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			f.lastUpdated, _ = body.CustomMetadata["last_updated"].(string)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			if !f.hasBeenWritten {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"custom_metadata": map[string]interface{}{"last_updated": f.lastUpdated},
+				},
+			})
+		}
+	})
+
+	return mux
+}
+
+func newTestStore(t *testing.T, fake *fakeVault) *Store {
+	t.Helper()
+
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	config := vaultapi.DefaultConfig()
+	config.Address = server.URL
+	client, err := vaultapi.NewClient(config)
+	if err != nil {
+		t.Fatalf("failed to create vault client: %v", err)
+	}
+
+	return New(client, "secret")
+}
+
+func TestStoreWriteReadRoundTrip(t *testing.T) {
+	fake := &fakeVault{}
+	store := newTestStore(t, fake)
+
+	if err := store.Write(context.Background(), "some/path", "some-value", nil); err != nil {
+		t.Fatalf("expected no error writing secret, got %v", err)
+	}
+
+	got, err := store.Read(context.Background(), "some/path")
+	if err != nil {
+		t.Fatalf("expected no error reading secret, got %v", err)
+	}
+	if got != "some-value" {
+		t.Fatalf("expected secret value %q, got %q", "some-value", got)
+	}
+}
+
+func TestStoreLastUpdated(t *testing.T) {
+	fake := &fakeVault{}
+	store := newTestStore(t, fake)
+
+	before := time.Now().UTC()
+	if err := store.Write(context.Background(), "some/path", "some-value", nil); err != nil {
+		t.Fatalf("expected no error writing secret, got %v", err)
+	}
+
+	got, err := store.LastUpdated(context.Background(), "some/path")
+	if err != nil {
+		t.Fatalf("expected no error getting last updated, got %v", err)
+	}
+	if got.Before(before.Add(-time.Minute)) {
+		t.Fatalf("expected last updated to be recent, got %v", got)
+	}
+}
+
+func TestStoreReadNotFound(t *testing.T) {
+	fake := &fakeVault{}
+	store := newTestStore(t, fake)
+
+	if _, err := store.Read(context.Background(), "some/path"); err != secrets.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStoreLastUpdatedNotFound(t *testing.T) {
+	fake := &fakeVault{}
+	store := newTestStore(t, fake)
+
+	if _, err := store.LastUpdated(context.Background(), "some/path"); err != secrets.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}